@@ -0,0 +1,185 @@
+package errors
+
+import "net/http"
+
+// ErrorCode classifies an error into a small, stable set of categories,
+// independent of its message. It is attached with WithCode and recovered
+// with Code, and is what HTTPStatus and GRPCStatus key off of when
+// mapping an error onto a transport-specific status.
+type ErrorCode string
+
+// The codes below cover the categories common to pingcap/errors and the
+// Thanos custom errors package. Add to this list as new categories are
+// needed; CodeUnknown is the fallback for errors that were never given
+// a code.
+const (
+	CodeUnknown          ErrorCode = "unknown"
+	CodeInvalidArgument  ErrorCode = "invalid_argument"
+	CodeNotFound         ErrorCode = "not_found"
+	CodeAlreadyExists    ErrorCode = "already_exists"
+	CodePermissionDenied ErrorCode = "permission_denied"
+	CodeUnauthenticated  ErrorCode = "unauthenticated"
+	CodeUnavailable      ErrorCode = "unavailable"
+	CodeInternal         ErrorCode = "internal"
+)
+
+// codedError decorates a cause with an ErrorCode. It carries no stack of
+// its own: the cause already has whatever stack New or Wrap attached,
+// and attaching a code is pure metadata, so there is nothing for
+// PopStack to trim here.
+type codedError struct {
+	cause error
+	code  ErrorCode
+}
+
+func (e *codedError) Error() string   { return e.cause.Error() }
+func (e *codedError) Unwrap() error   { return e.cause }
+func (e *codedError) Code() ErrorCode { return e.code }
+
+// WithCode classifies err with the given ErrorCode. The code survives
+// further wrapping and can be recovered anywhere downstream with Code.
+// WithCode on a nil error returns nil.
+func WithCode(err error, code ErrorCode) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{cause: err, code: code}
+}
+
+// Code walks err's chain via As and returns the ErrorCode attached by
+// the nearest WithCode call, or CodeUnknown if none was ever attached.
+func Code(err error) ErrorCode {
+	var coder interface{ Code() ErrorCode }
+	if As(err, &coder) {
+		return coder.Code()
+	}
+	return CodeUnknown
+}
+
+// fieldsError decorates a cause with structured key/value context. Like
+// codedError, it is pure metadata and does not touch the stack trace.
+type fieldsError struct {
+	cause  error
+	fields map[string]any
+}
+
+func (e *fieldsError) Error() string { return e.cause.Error() }
+func (e *fieldsError) Unwrap() error { return e.cause }
+
+// WithFields attaches arbitrary structured context to err as alternating
+// key/value pairs, e.g. WithFields(err, "user_id", id, "attempt", n). A
+// key that isn't a string is dropped along with its value. The fields
+// survive further wrapping and are recovered anywhere downstream with
+// Fields. WithFields on a nil error returns nil.
+func WithFields(err error, kv ...any) error {
+	if err == nil {
+		return nil
+	}
+	fields := make(map[string]any, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			continue
+		}
+		fields[key] = kv[i+1]
+	}
+	return &fieldsError{cause: err, fields: fields}
+}
+
+// Fields walks err's chain collecting every WithFields attachment into a
+// single map. Where the same key was attached more than once, the
+// attachment closest to err wins. Like Code, the walk descends into
+// Unwrap() []error branches (e.g. a Join), not just Unwrap() error, so a
+// field attached to any joined error is found. Fields on an error with
+// no attachments returns an empty, non-nil map.
+func Fields(err error) map[string]any {
+	fields := make(map[string]any)
+	collectFields(err, fields)
+	return fields
+}
+
+func collectFields(err error, fields map[string]any) {
+	if err == nil {
+		return
+	}
+
+	if fe, ok := err.(*fieldsError); ok {
+		for k, v := range fe.fields {
+			if _, exists := fields[k]; !exists {
+				fields[k] = v
+			}
+		}
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, child := range x.Unwrap() {
+			collectFields(child, fields)
+		}
+	case interface{ Unwrap() error }:
+		collectFields(x.Unwrap(), fields)
+	}
+}
+
+// HTTPStatus maps err's Code to the http status code that best conveys
+// it, following the same category-to-status convention as pingcap/errors.
+// Errors without a recognised code map to http.StatusInternalServerError.
+func HTTPStatus(err error) int {
+	switch Code(err) {
+	case CodeInvalidArgument:
+		return http.StatusBadRequest
+	case CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case CodePermissionDenied:
+		return http.StatusForbidden
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeAlreadyExists:
+		return http.StatusConflict
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCCode mirrors the numeric values of google.golang.org/grpc/codes.Code.
+// It is defined locally so that this package stays free of a grpc
+// dependency; callers that already depend on grpc can convert it with a
+// plain codes.Code(errors.GRPCStatus(err)).
+type GRPCCode uint32
+
+// Numeric values match google.golang.org/grpc/codes exactly.
+const (
+	GRPCCodeUnknown          GRPCCode = 2
+	GRPCCodeInvalidArgument  GRPCCode = 3
+	GRPCCodeNotFound         GRPCCode = 5
+	GRPCCodeAlreadyExists    GRPCCode = 6
+	GRPCCodePermissionDenied GRPCCode = 7
+	GRPCCodeInternal         GRPCCode = 13
+	GRPCCodeUnavailable      GRPCCode = 14
+	GRPCCodeUnauthenticated  GRPCCode = 16
+)
+
+// GRPCStatus maps err's Code to the matching GRPCCode. Errors without a
+// recognised code map to GRPCCodeUnknown.
+func GRPCStatus(err error) GRPCCode {
+	switch Code(err) {
+	case CodeInvalidArgument:
+		return GRPCCodeInvalidArgument
+	case CodeUnauthenticated:
+		return GRPCCodeUnauthenticated
+	case CodePermissionDenied:
+		return GRPCCodePermissionDenied
+	case CodeNotFound:
+		return GRPCCodeNotFound
+	case CodeAlreadyExists:
+		return GRPCCodeAlreadyExists
+	case CodeUnavailable:
+		return GRPCCodeUnavailable
+	case CodeInternal:
+		return GRPCCodeInternal
+	default:
+		return GRPCCodeUnknown
+	}
+}
@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"net/http"
+
+	errs "github.com/dairlair/go-errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Codes and fields", func() {
+	It("recovers the code attached by WithCode through further wrapping", func() {
+		err := errs.WithCode(errs.New("missing"), errs.CodeNotFound)
+		wrapped := errs.Wrap(err, "while loading")
+
+		Expect(errs.Code(wrapped)).To(Equal(errs.CodeNotFound))
+	})
+
+	It("defaults to CodeUnknown when no code was attached", func() {
+		Expect(errs.Code(errs.New("plain"))).To(Equal(errs.CodeUnknown))
+	})
+
+	It("recovers fields attached by WithFields through further wrapping", func() {
+		err := errs.WithFields(errs.New("missing"), "user_id", 42)
+		wrapped := errs.Wrap(err, "while loading")
+
+		Expect(errs.Fields(wrapped)).To(HaveKeyWithValue("user_id", 42))
+	})
+
+	It("maps codes onto the matching http status", func() {
+		Expect(errs.HTTPStatus(errs.WithCode(errs.New("x"), errs.CodeNotFound))).To(Equal(http.StatusNotFound))
+		Expect(errs.HTTPStatus(errs.New("x"))).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("maps codes onto the matching grpc code", func() {
+		Expect(errs.GRPCStatus(errs.WithCode(errs.New("x"), errs.CodePermissionDenied))).To(Equal(errs.GRPCCodePermissionDenied))
+	})
+
+	It("finds Code and Fields attached to any branch of a Join, consistently", func() {
+		branchA := errs.WithFields(errs.WithCode(errs.New("a"), errs.CodeNotFound), "k1", "v1")
+		branchB := errs.New("b")
+		joined := errs.Join(branchA, branchB)
+
+		Expect(errs.Code(joined)).To(Equal(errs.CodeNotFound))
+		Expect(errs.Fields(joined)).To(HaveKeyWithValue("k1", "v1"))
+	})
+})
@@ -0,0 +1,34 @@
+package errors
+
+// maxStackDepth and skipCallers tune callers(); see SetMaxStackDepth and
+// SetSkipCallers below.
+var (
+	maxStackDepth = 32
+	skipCallers   = 0
+)
+
+// IncludeBacktrace controls whether New, Wrap, and Join capture a stack
+// trace at all, as go-ap/errors exposes. Set it to false in production
+// builds that want to avoid the cost of runtime.Callers on every error;
+// with it false those constructors fall back to plain, stack-free
+// errors, and Frames/StackTrace on them report nothing.
+var IncludeBacktrace = true
+
+// SetMaxStackDepth overrides the default 32-frame buffer that callers
+// captures into. That buffer holds the fresh stack Join records and the
+// stacks compared in the ancestor checks of Wrap, WrapWithSkip and Join.
+// The stacks that New, NewWithSkip, Wrap and WrapWithSkip attach are
+// captured by pkg/errors, whose 32-frame buffer this does not change.
+func SetMaxStackDepth(depth int) {
+	maxStackDepth = depth
+}
+
+// SetSkipCallers adds extra frames to skip on every capture made by
+// callers: the fresh stack Join records and the stacks compared in the
+// ancestor checks of Wrap, WrapWithSkip and Join. The stacks that New,
+// NewWithSkip, Wrap and WrapWithSkip attach are captured by pkg/errors
+// and trimmed only by their own PopStack calls and the skip passed to
+// NewWithSkip/WrapWithSkip; SetSkipCallers does not change them.
+func SetSkipCallers(skip int) {
+	skipCallers = skip
+}
@@ -0,0 +1,67 @@
+package errors_test
+
+import (
+	errs "github.com/dairlair/go-errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stack capture configuration", func() {
+	AfterEach(func() {
+		errs.IncludeBacktrace = true
+		errs.SetMaxStackDepth(32)
+		errs.SetSkipCallers(0)
+	})
+
+	It("captures no stack at all when IncludeBacktrace is false", func() {
+		errs.IncludeBacktrace = false
+
+		err := errs.New("boom")
+		_, ok := err.(errs.StackTracer)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("captures a stack again once IncludeBacktrace is restored", func() {
+		errs.IncludeBacktrace = false
+		errs.IncludeBacktrace = true
+
+		err := errs.New("boom")
+		tracer, ok := err.(errs.StackTracer)
+		Expect(ok).To(BeTrue())
+		Expect(tracer.StackTrace()).NotTo(BeEmpty())
+	})
+
+	It("elides the requested number of extra frames with NewWithSkip", func() {
+		skip0 := errs.NewWithSkip(0, "boom")
+		skip1 := errs.NewWithSkip(1, "boom")
+
+		Expect(len(errs.Frames(skip1))).To(Equal(len(errs.Frames(skip0)) - 1))
+	})
+})
+
+var _ = Describe("PopStack", func() {
+	It("defaults to popping a single frame", func() {
+		err := errs.New("boom")
+		before := len(errs.Frames(err))
+
+		errs.PopStack(err)
+
+		Expect(len(errs.Frames(err))).To(Equal(before - 1))
+	})
+
+	It("pops the requested count", func() {
+		err := errs.New("boom")
+		before := len(errs.Frames(err))
+
+		errs.PopStack(err, 2)
+
+		Expect(len(errs.Frames(err))).To(Equal(before - 2))
+	})
+
+	It("leaves the stack empty instead of panicking when count exceeds its length", func() {
+		err := errs.New("boom")
+
+		Expect(func() { errs.PopStack(err, 1000) }).NotTo(Panic())
+		Expect(errs.Frames(err)).To(BeEmpty())
+	})
+})
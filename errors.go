@@ -44,9 +44,22 @@ func Sentinel(msg string, args ...interface{}) error {
 // New acts creates a stack traced error from a message with interpolated parameters.
 // It can be used when you want the stack trace to begin at the point where the error was created.
 func New(msg string, args ...interface{}) error {
+	if !IncludeBacktrace {
+		return fmt.Errorf("%s", fmt.Sprintf(msg, args...))
+	}
 	return PopStack(errors.New(fmt.Sprintf(msg, args...)))
 }
 
+// NewWithSkip is New with skip additional frames elided from the top of
+// the stack trace, for library code that wants its own wrapper frames
+// left out of every stack it produces.
+func NewWithSkip(skip int, msg string, args ...interface{}) error {
+	if !IncludeBacktrace {
+		return fmt.Errorf("%s", fmt.Sprintf(msg, args...))
+	}
+	return PopStack(errors.New(fmt.Sprintf(msg, args...)), 1+skip)
+}
+
 // Wrap creates a new error by decorating the original error message with a prefix.
 // It differs from the Wrap and Wrapf functions in the pkg/errors package in that
 // it idempotently creates a stack trace.
@@ -55,6 +68,10 @@ func New(msg string, args ...interface{}) error {
 // stack trace present in the error. This helps in avoiding the creation of
 // redundant stack traces, making error handling more efficient.
 func Wrap(cause error, msg string, args ...interface{}) error {
+	if !IncludeBacktrace {
+		return errors.WithMessagef(cause, msg, args...)
+	}
+
 	causeStackTracer := new(StackTracer)
 	if errors.As(cause, causeStackTracer) {
 		// If our function's cause has generated a stack trace and it is a sub-stack of our function,
@@ -71,6 +88,25 @@ func Wrap(cause error, msg string, args ...interface{}) error {
 	return PopStack(errors.Wrapf(cause, msg, args...))
 }
 
+// WrapWithSkip is Wrap with skip additional frames elided from the top
+// of the new stack trace, for library code that wants its own wrapper
+// frames left out. The skip has no effect when Wrap would reuse the
+// cause's existing stack instead of generating a new one.
+func WrapWithSkip(skip int, cause error, msg string, args ...interface{}) error {
+	if !IncludeBacktrace {
+		return errors.WithMessagef(cause, msg, args...)
+	}
+
+	causeStackTracer := new(StackTracer)
+	if errors.As(cause, causeStackTracer) {
+		if ancestorOfCause(callers(1+skip), (*causeStackTracer).StackTrace()) {
+			return errors.WithMessagef(cause, msg, args...) // no stack - no pop
+		}
+	}
+
+	return PopStack(errors.Wrapf(cause, msg, args...), 1+skip)
+}
+
 // The function returns 'true' if the calling function is an ancestor of the error stack trace.
 //
 // Determines whether the calling function is an ancestor of the provided stack trace.
@@ -128,12 +164,101 @@ func ancestorOfCause(ourStack []uintptr, causeStack errors.StackTrace) bool {
 }
 
 func callers(skip int) []uintptr {
-	pc := make([]uintptr, 32)        // expect a maximum of 32 levels of function call hierarchy
-	n := runtime.Callers(skip+3, pc) // capture those frames, skipping runtime.Callers, ourself and the calling function
+	pc := make([]uintptr, maxStackDepth)         // expect at most maxStackDepth levels of function call hierarchy
+	n := runtime.Callers(skip+3+skipCallers, pc) // capture those frames, skipping runtime.Callers, ourself and the calling function
 
 	return pc[:n] // return captured frames
 }
 
+// stack is our own minimal mirror of the unexported stack type that
+// pkg/errors attaches to the errors it creates. We need it because Join
+// has no pkg/errors constructor to borrow a stack trace from, yet still
+// wants to satisfy StackTracer the same way every other error in this
+// package does.
+type stack []uintptr
+
+// StackTrace converts the raw program counters into a errors.StackTrace,
+// following the exact conversion pkg/errors itself performs internally.
+func (s *stack) StackTrace() errors.StackTrace {
+	frames := make(errors.StackTrace, len(*s))
+	for i := range frames {
+		frames[i] = errors.Frame((*s)[i])
+	}
+	return frames
+}
+
+// joinError is the concrete type returned by Join. It implements
+// Unwrap() []error so that the As and Is helpers re-exported above -
+// which are themselves backed by the standard library since pkg/errors
+// v0.9 - can walk into every joined error in turn.
+type joinError struct {
+	errs     []error
+	stack    *stack
+	delegate StackTracer
+}
+
+func (e *joinError) Error() string {
+	var b []byte
+	for i, err := range e.errs {
+		if i > 0 {
+			b = append(b, '\n')
+		}
+		b = append(b, err.Error()...)
+	}
+	return string(b)
+}
+
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
+
+func (e *joinError) StackTrace() errors.StackTrace {
+	if e.delegate != nil {
+		return e.delegate.StackTrace()
+	}
+	if e.stack == nil {
+		return nil
+	}
+	return e.stack.StackTrace()
+}
+
+// Join combines multiple errors into a single error, in the spirit of
+// the standard library's errors.Join: the result implements
+// Unwrap() []error, so Is reports a match if any joined error matches
+// and As populates from the first joined error that matches. Nil errors
+// passed in are dropped, and Join returns nil if every argument is nil.
+//
+// Like Wrap, Join idempotently captures its stack trace: if one of the
+// joined errors already carries a stack that is an ancestor of the
+// current callers, that existing stack is reused and no new frame is
+// recorded for the Join call site itself.
+func Join(errs ...error) error {
+	joined := &joinError{errs: make([]error, 0, len(errs))}
+	for _, err := range errs {
+		if err != nil {
+			joined.errs = append(joined.errs, err)
+		}
+	}
+	if len(joined.errs) == 0 {
+		return nil
+	}
+	if !IncludeBacktrace {
+		return joined
+	}
+
+	causeStackTracer := new(StackTracer)
+	for _, err := range joined.errs {
+		if errors.As(err, causeStackTracer) && ancestorOfCause(callers(1), (*causeStackTracer).StackTrace()) {
+			joined.delegate = *causeStackTracer
+			return joined
+		}
+	}
+
+	s := stack(callers(0))
+	joined.stack = &s
+	return joined
+}
+
 // RecoverPanic  designed to transform a panic event into an error.
 //
 // Additionally, the function modifies the stack trace of the error such
@@ -147,35 +272,94 @@ func callers(skip int) []uintptr {
 //	  }()
 //	}
 func RecoverPanic(r interface{}, errPtr *error) {
-	var err error
-	if r != nil {
-		if panicErr, ok := r.(error); ok {
-			err = errors.Wrap(panicErr, "caught panic")
+	if r == nil {
+		return
+	}
+
+	panicErr, ok := r.(error)
+
+	if !IncludeBacktrace {
+		if ok {
+			*errPtr = fmt.Errorf("caught panic: %w", panicErr)
 		} else {
-			err = errors.New(fmt.Sprintf("caught panic: %v", r))
+			*errPtr = fmt.Errorf("caught panic: %v", r)
 		}
+		return
+	}
+
+	var err error
+	if ok {
+		err = errors.Wrap(panicErr, "caught panic")
+	} else {
+		err = errors.New(fmt.Sprintf("caught panic: %v", r))
 	}
 
-	if err != nil {
-		// Two pop operations are necessary within the function in order to remove the relevant stack frames.
-		// The first pop is needed to remove the 'errors' package,
-		// while the second pop is required to remove the defer function that encapsulates
-		// the error handling infrastructure.
-		// The goal of these 'pop' operations is to adjust the stack trace so that it originates
-		// from the line of code that triggered the panic event, rather than the error handling code.
-		err = PopStack(err) // errors.go
-		err = PopStack(err) // defer
+	// Two pop operations are necessary within the function in order to remove the relevant stack frames.
+	// The first pop is needed to remove the 'errors' package,
+	// while the second pop is required to remove the defer function that encapsulates
+	// the error handling infrastructure.
+	// The goal of these 'pop' operations is to adjust the stack trace so that it originates
+	// from the line of code that triggered the panic event, rather than the error handling code.
+	err = PopStack(err) // errors.go
+	err = PopStack(err) // defer
 
-		*errPtr = err
+	*errPtr = err
+}
+
+// RecoverPanicWithSkip is RecoverPanic for callers that sit skip frames
+// below it instead of being invoked directly from the user's defer
+// closure - e.g. RecoverAndReport, which wraps this function in one
+// more frame of its own.
+func RecoverPanicWithSkip(r interface{}, errPtr *error, skip int) {
+	if r == nil {
+		return
 	}
+
+	panicErr, ok := r.(error)
+
+	if !IncludeBacktrace {
+		if ok {
+			*errPtr = fmt.Errorf("caught panic: %w", panicErr)
+		} else {
+			*errPtr = fmt.Errorf("caught panic: %v", r)
+		}
+		return
+	}
+
+	var err error
+	if ok {
+		err = errors.Wrap(panicErr, "caught panic")
+	} else {
+		err = errors.New(fmt.Sprintf("caught panic: %v", r))
+	}
+
+	// See RecoverPanic: the first pop removes this function's own frame
+	// plus skip additional wrapper frames sitting between it and the
+	// user's defer closure; the second pop removes that defer closure.
+	err = PopStack(err, 1+skip) // errors.go (+ skip)
+	err = PopStack(err)         // defer
+
+	*errPtr = err
 }
 
-// PopStack used to remove the top element from a stack trace.
-func PopStack(err error) error {
+// PopStack removes the top element from a stack trace. An optional count
+// removes that many elements instead of just one; count defaults to 1
+// when omitted, preserving PopStack's original single-frame behaviour.
+// A count at or beyond the length of the stack leaves it empty rather
+// than panicking.
+func PopStack(err error, count ...int) error {
 	if err == nil {
 		return err
 	}
 
+	n := 1
+	if len(count) > 0 {
+		n = count[0]
+	}
+	if n <= 0 {
+		return err
+	}
+
 	// We need to remove the 'errors.New' function from a newly created error stack. However,
 	// there is no public method for modifying the error stack, as it is stored as a
 	// private field within an unexported struct.
@@ -188,8 +372,12 @@ func PopStack(err error) error {
 	}
 	stackFieldPtr := (**[]uintptr)(unsafe.Pointer(stackField.UnsafeAddr()))
 
-	// Remove the first frame from a stack trace, effectively eliminating the element associated with 'us' from the error stack.
-	frames := (**stackFieldPtr)[1:]
+	// Remove the top n frames from the stack trace, effectively eliminating the elements associated with 'us' from the error stack.
+	current := **stackFieldPtr
+	if n > len(current) {
+		n = len(current)
+	}
+	frames := current[n:]
 
 	// Assign to the internal stack field
 	*stackFieldPtr = &frames
@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+)
+
+// Frame describes a single stack frame in the file/line/function shape
+// that external reporters want, mirroring what bugsnag-go extracts from
+// pkg/errors stack traces.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Frames resolves the innermost StackTracer in err's chain - the one
+// closest to where the error was first created - into runtime.Frame
+// values via runtime.CallersFrames, so that reporters don't need to
+// duplicate the unsafe reflection PopStack relies on.
+func Frames(err error) []runtime.Frame {
+	tracer := innermostStackTracer(err)
+	if tracer == nil {
+		return nil
+	}
+
+	trace := tracer.StackTrace()
+	if len(trace) == 0 {
+		return nil
+	}
+	pcs := make([]uintptr, len(trace))
+	for i, pc := range trace {
+		pcs[i] = uintptr(pc)
+	}
+
+	framesIter := runtime.CallersFrames(pcs)
+	var frames []runtime.Frame
+	for {
+		frame, more := framesIter.Next()
+		frames = append(frames, frame)
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// innermostStackTracer walks err's chain and returns the last - i.e.
+// deepest - StackTracer it finds, since that is the one whose stack was
+// captured closest to where the error originated.
+func innermostStackTracer(err error) StackTracer {
+	var innermost StackTracer
+	for err != nil {
+		if tracer, ok := err.(StackTracer); ok {
+			innermost = tracer
+		}
+		err = Unwrap(err)
+	}
+	return innermost
+}
+
+// Formatted is a structured, JSON-marshalable snapshot of an error
+// chain: its top-level message, the message of every cause beneath it,
+// and the stack trace of the innermost StackTracer rendered as
+// file/line/function frames. It exists so that code shipping errors to
+// Sentry/Rollbar/structured loggers doesn't have to walk the chain
+// itself to get something more useful than err.Error().
+type Formatted struct {
+	Message string   `json:"message"`
+	Causes  []string `json:"causes,omitempty"`
+	Stack   []Frame  `json:"stack,omitempty"`
+}
+
+// Format builds a Formatted snapshot of err.
+func Format(err error) Formatted {
+	if err == nil {
+		return Formatted{}
+	}
+
+	f := Formatted{Message: err.Error()}
+	for cause := Unwrap(err); cause != nil; cause = Unwrap(cause) {
+		f.Causes = append(f.Causes, cause.Error())
+	}
+	for _, rf := range Frames(err) {
+		f.Stack = append(f.Stack, Frame{Function: rf.Function, File: rf.File, Line: rf.Line})
+	}
+	return f
+}
+
+// MarshalJSON implements json.Marshaler, so a Formatted snapshot can be
+// passed directly to json.Marshal, or embedded in a larger payload, and
+// renders as its message, causes and stack fields.
+func (f Formatted) MarshalJSON() ([]byte, error) {
+	type alias Formatted // avoid recursing back into this method
+	return json.Marshal(alias(f))
+}
+
+// Format implements fmt.Formatter in the go-ap/errors style: %s and %v
+// print the message chain only, while %+v additionally appends the
+// stack trace, each frame as its function on one line followed by its
+// tab-indented file:line on the next. This is what lets
+// fmt.Sprintf("%+v", errors.Format(err)) print the chain plus stack.
+func (f Formatted) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		io.WriteString(s, f.Message)
+		for _, cause := range f.Causes {
+			fmt.Fprintf(s, ": %s", cause)
+		}
+		if verb == 'v' && s.Flag('+') {
+			for _, frame := range f.Stack {
+				fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+		}
+	}
+}
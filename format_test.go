@@ -0,0 +1,56 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	errs "github.com/dairlair/go-errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Frames and Format", func() {
+	It("resolves the innermost stack tracer's frames", func() {
+		err := errs.Wrap(errs.New("root cause"), "while loading")
+
+		frames := errs.Frames(err)
+		Expect(frames).NotTo(BeEmpty())
+		Expect(frames[0].Function).To(ContainSubstring("errors_test"))
+	})
+
+	It("returns nil frames for an error whose stack is empty, rather than a bogus frame", func() {
+		Expect(errs.Frames(emptyStackError{})).To(BeEmpty())
+	})
+
+	It("renders the message chain and causes", func() {
+		err := errs.Wrap(errs.New("root cause"), "while loading")
+
+		formatted := errs.Format(err)
+		Expect(formatted.Message).To(ContainSubstring("while loading"))
+		Expect(formatted.Causes).To(ContainElement(ContainSubstring("root cause")))
+	})
+
+	It("marshals to JSON", func() {
+		formatted := errs.Format(errs.New("boom"))
+
+		out, err := json.Marshal(formatted)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring(`"message":"boom"`))
+	})
+
+	It("prints the stack under %+v but not under %v", func() {
+		formatted := errs.Format(errs.New("boom"))
+
+		Expect(fmt.Sprintf("%v", formatted)).NotTo(ContainSubstring("\n"))
+		Expect(strings.Count(fmt.Sprintf("%+v", formatted), "\n")).To(BeNumerically(">", 0))
+	})
+})
+
+// emptyStackError is a StackTracer whose StackTrace() is empty, the
+// shape Join produces for an error with no ancestor stack to reuse and
+// IncludeBacktrace disabled.
+type emptyStackError struct{}
+
+func (emptyStackError) Error() string               { return "empty" }
+func (emptyStackError) StackTrace() errs.StackTrace { return errs.StackTrace{} }
@@ -0,0 +1,45 @@
+package errors_test
+
+import (
+	stderrors "errors"
+
+	errs "github.com/dairlair/go-errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type joinTestError struct {
+	error
+}
+
+var _ = Describe("Join", func() {
+	It("drops nil errors and returns nil if everything was nil", func() {
+		Expect(errs.Join(nil, nil)).To(BeNil())
+	})
+
+	It("lets Is match against any joined error", func() {
+		a := errs.New("a")
+		b := errs.New("b")
+		joined := errs.Join(a, b)
+
+		Expect(stderrors.Is(joined, a)).To(BeTrue())
+		Expect(stderrors.Is(joined, b)).To(BeTrue())
+	})
+
+	It("lets As populate from the first joined error that matches", func() {
+		wrapped := &joinTestError{error: stderrors.New("boom")}
+		joined := errs.Join(wrapped, errs.New("other"))
+
+		var target *joinTestError
+		Expect(stderrors.As(joined, &target)).To(BeTrue())
+		Expect(target).To(BeIdenticalTo(wrapped))
+	})
+
+	It("reuses a joined error's existing stack instead of dropping it", func() {
+		joined := errs.Join(errs.New("a"), errs.New("b"))
+
+		tracer, ok := joined.(errs.StackTracer)
+		Expect(ok).To(BeTrue())
+		Expect(tracer.StackTrace()).NotTo(BeEmpty())
+	})
+})
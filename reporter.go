@@ -0,0 +1,73 @@
+package errors
+
+// ErrorReporter is satisfied by adapters for external services such as
+// Sentry, Bugsnag, or Rollbar. Report receives the error RecoverAndReport
+// built from the panic, with its stack trace already corrected to
+// originate at the panicking line the same way RecoverPanic does.
+// Adapters pull file/line/function frames off it with Frames, the same
+// way raven-go and bugsnag-go pull frames off pkg/errors stack traces.
+// This package itself stays dependency-free; the adapters live outside it.
+type ErrorReporter interface {
+	Report(err error)
+}
+
+// ReporterFunc adapts a plain func(error) to ErrorReporter.
+type ReporterFunc func(error)
+
+// Report calls f(err).
+func (f ReporterFunc) Report(err error) { f(err) }
+
+var reporters []ErrorReporter
+
+// RegisterReporter adds a reporter that RecoverAndReport dispatches every
+// recovered panic to, in addition to converting it into a returned error.
+// Reporters are invoked in registration order.
+func RegisterReporter(report func(error)) {
+	reporters = append(reporters, ReporterFunc(report))
+}
+
+// RecoverAndReportOption configures RecoverAndReport.
+type RecoverAndReportOption func(*recoverAndReportConfig)
+
+type recoverAndReportConfig struct {
+	rethrow bool
+}
+
+// WithRethrow controls whether RecoverAndReport re-panics with the
+// original value after dispatching it to every registered reporter. It
+// is off by default, matching RecoverPanic's behaviour of converting the
+// panic into a returned error rather than letting it propagate. Pass
+// WithRethrow(true) when a supervisor further up the stack also needs
+// to observe the panic.
+func WithRethrow(rethrow bool) RecoverAndReportOption {
+	return func(c *recoverAndReportConfig) { c.rethrow = rethrow }
+}
+
+// RecoverAndReport extends RecoverPanic with reporting: on panic, it
+// builds the error exactly as RecoverPanic does, assigns it to errPtr,
+// and dispatches it to every reporter registered with RegisterReporter.
+//
+//	func Do() (err error) {
+//	  defer func() {
+//	    errors.RecoverAndReport(recover(), &err)
+//	  }()
+//	}
+func RecoverAndReport(r interface{}, errPtr *error, opts ...RecoverAndReportOption) {
+	if r == nil {
+		return
+	}
+
+	var cfg recoverAndReportConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	RecoverPanicWithSkip(r, errPtr, 1)
+	for _, reporter := range reporters {
+		reporter.Report(*errPtr)
+	}
+
+	if cfg.rethrow {
+		panic(r)
+	}
+}
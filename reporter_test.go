@@ -0,0 +1,54 @@
+package errors_test
+
+import (
+	errs "github.com/dairlair/go-errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func panicObserved(report func(interface{}, *error)) (err error) {
+	defer func() {
+		report(recover(), &err)
+	}()
+	panic("boom")
+}
+
+var _ = Describe("RecoverAndReport", func() {
+	It("dispatches the recovered error to every registered reporter", func() {
+		var reported []error
+		errs.RegisterReporter(func(err error) { reported = append(reported, err) })
+
+		err := panicObserved(func(r interface{}, errPtr *error) {
+			errs.RecoverAndReport(r, errPtr)
+		})
+
+		Expect(err).To(HaveOccurred())
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0]).To(Equal(err))
+	})
+
+	It("points its stack at the panicking line, the same as RecoverPanic does directly", func() {
+		direct := panicObserved(func(r interface{}, errPtr *error) {
+			errs.RecoverPanic(r, errPtr)
+		})
+		viaReport := panicObserved(func(r interface{}, errPtr *error) {
+			errs.RecoverAndReport(r, errPtr)
+		})
+
+		directFrames := errs.Frames(direct)
+		viaReportFrames := errs.Frames(viaReport)
+
+		Expect(directFrames).NotTo(BeEmpty())
+		Expect(viaReportFrames).NotTo(BeEmpty())
+		Expect(viaReportFrames[0].Function).To(Equal(directFrames[0].Function))
+		Expect(viaReportFrames[0].Line).To(Equal(directFrames[0].Line))
+	})
+
+	It("re-panics with the original value when WithRethrow(true) is set", func() {
+		Expect(func() {
+			_ = panicObserved(func(r interface{}, errPtr *error) {
+				errs.RecoverAndReport(r, errPtr, errs.WithRethrow(true))
+			})
+		}).To(PanicWith("boom"))
+	})
+})